@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// cacheDir is where reporter keeps its on-disk HTTP cache.
+func cacheDir() string {
+	return os.Getenv("HOME") + "/.cache/reporter"
+}
+
+// cacheEntry is what gets persisted to disk for one cached GET response.
+type cacheEntry struct {
+	URL          string      `json:"url"`
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"last_modified,omitempty"`
+	StatusCode   int         `json:"status_code"`
+	Header       http.Header `json:"header"`
+	Body         []byte      `json:"body"`
+}
+
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		StatusCode: e.StatusCode,
+		Status:     http.StatusText(e.StatusCode),
+		Header:     e.Header,
+		Body:       ioutil.NopCloser(bytes.NewReader(e.Body)),
+		Request:    req,
+	}
+}
+
+func cacheKey(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(sum[:])
+}
+
+func cacheEntryPath(key string) string {
+	return filepath.Join(cacheDir(), key+".json")
+}
+
+func loadCacheEntry(key string) *cacheEntry {
+	data, err := ioutil.ReadFile(cacheEntryPath(key))
+	if err != nil {
+		return nil
+	}
+	var entry cacheEntry
+	if json.Unmarshal(data, &entry) != nil {
+		return nil
+	}
+	return &entry
+}
+
+func saveCacheEntry(key string, entry *cacheEntry) error {
+	if err := os.MkdirAll(cacheDir(), 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(cacheEntryPath(key), data, 0o644)
+}
+
+// maxRateLimitRetries bounds how many times we'll sleep through a rate
+// limit before giving up and returning the 403/429 to the caller.
+const maxRateLimitRetries = 3
+
+// cachingTransport is an http.RoundTripper that caches GET responses on
+// disk keyed by URL, replaying them via GitHub's conditional requests
+// (If-None-Match / If-Modified-Since) so an unchanged page doesn't consume
+// rate-limit budget. It also backs off and retries when GitHub reports
+// the rate limit is exhausted.
+type cachingTransport struct {
+	base http.RoundTripper
+}
+
+func newCachingTransport(base http.RoundTripper) *cachingTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &cachingTransport{base: base}
+}
+
+func (t *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.roundTripWithBackoff(req)
+	}
+
+	key := cacheKey(req.URL.String())
+	entry := loadCacheEntry(key)
+	if entry != nil {
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	resp, err := t.roundTripWithBackoff(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		resp.Body.Close()
+		return entry.response(req), nil
+	}
+	if resp.StatusCode == http.StatusOK {
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		saveCacheEntry(key, &cacheEntry{
+			URL:          req.URL.String(),
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			StatusCode:   resp.StatusCode,
+			Header:       resp.Header,
+			Body:         body,
+		})
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	return resp, nil
+}
+
+// roundTripWithBackoff sleeps and retries when GitHub reports the primary
+// rate limit is exhausted (X-RateLimit-Remaining: 0) or a secondary rate
+// limit kicked in (Retry-After set regardless of remaining budget).
+func (t *cachingTransport) roundTripWithBackoff(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		limited := resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests
+		rateLimited := limited && (resp.Header.Get("X-RateLimit-Remaining") == "0" || resp.Header.Get("Retry-After") != "")
+		if !rateLimited || attempt >= maxRateLimitRetries {
+			return resp, nil
+		}
+		wait := rateLimitWait(resp.Header)
+		resp.Body.Close()
+		time.Sleep(wait)
+	}
+}
+
+func rateLimitWait(h http.Header) time.Duration {
+	if ra := h.Get("Retry-After"); ra != "" {
+		if secs, err := strconv.Atoi(ra); err == nil {
+			return time.Duration(secs) * time.Second
+		}
+	}
+	if reset := h.Get("X-RateLimit-Reset"); reset != "" {
+		if epoch, err := strconv.ParseInt(reset, 10, 64); err == nil {
+			if d := time.Until(time.Unix(epoch, 0)); d > 0 {
+				return d
+			}
+		}
+	}
+	return 60 * time.Second
+}
+
+// cmdCachePrune clears the on-disk HTTP cache.
+func cmdCachePrune(app *cli.Context) error {
+	dir := cacheDir()
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			fmt.Println("Cache is already empty.")
+			return nil
+		}
+		return err
+	}
+	removed := 0
+	for _, e := range entries {
+		if err := os.Remove(filepath.Join(dir, e.Name())); err != nil {
+			return err
+		}
+		removed++
+	}
+	fmt.Printf("Pruned %d cache entries.\n", removed)
+	return nil
+}
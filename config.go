@@ -0,0 +1,51 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+
+	"gopkg.in/yaml.v2"
+)
+
+// configDir is where reporter keeps its config files, next to
+// github_token.txt.
+func configDir() string {
+	return os.Getenv("HOME") + "/.config/reporter"
+}
+
+// GerritConfig holds the credentials reporter uses against a Gerrit host's
+// authenticated REST endpoint (the /a/ prefix).
+type GerritConfig struct {
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// ForgesConfig is the contents of forges.yml, keyed by Gerrit host name.
+type ForgesConfig struct {
+	Gerrit map[string]GerritConfig `yaml:"gerrit"`
+}
+
+// loadForgesConfig reads forges.yml if present; a missing file is not an
+// error since Gerrit can be used anonymously for read-only reports.
+func loadForgesConfig() (*ForgesConfig, error) {
+	cfg := &ForgesConfig{}
+	data, err := ioutil.ReadFile(configDir() + "/forges.yml")
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (c *ForgesConfig) gerritAuth(host string) (string, string, bool) {
+	g, ok := c.Gerrit[host]
+	if !ok || g.Username == "" {
+		return "", "", false
+	}
+	return g.Username, g.Password, true
+}
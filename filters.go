@@ -2,28 +2,31 @@ package main
 
 import (
 	"fmt"
-	"github.com/google/go-github/v31/github"
 	"time"
 )
 
 type TicketFilter interface {
-	CheckTicket(i *github.Issue) bool
+	CheckTicket(i *Issue) bool
 }
 
 type PrFilter interface {
-	CheckPr(pr *github.PullRequest) bool
+	CheckPr(pr *PullRequest) bool
 }
 
 type TimeFilter struct {
 	Range DateRange
 }
 
-func (f *TimeFilter) CheckTicket(i *github.Issue) bool {
+// CheckTicket goes by an issue's created-at, since that's the moment a
+// contributor picked it up.
+func (f *TimeFilter) CheckTicket(i *Issue) bool {
 	return f.Range.Include(i.CreatedAt)
 }
 
-func (f *TimeFilter) CheckPr(pr *github.PullRequest) bool {
-	return f.Range.Include(pr.CreatedAt)
+// CheckPr goes by a PR's closed-at, since that's when the work it
+// represents actually landed (or was dropped).
+func (f *TimeFilter) CheckPr(pr *PullRequest) bool {
+	return f.Range.Include(pr.ClosedAt)
 }
 
 // DateRange - checks if time is in range
@@ -32,37 +35,63 @@ type DateRange interface {
 	Include(t *time.Time) bool
 }
 
-// ParseRange from string
+// ParseRange from a named period: daily, weekly, monthly or quarterly.
+// Use ParseFixedRange for an explicit --from/--to range.
 func ParseRange(name string) (DateRange, error) {
 	now := time.Now()
-	if name == "daily" {
+	switch name {
+	case "daily":
 		return &DailyRange{&now}, nil
-	}
-	if name == "weekly" {
+	case "weekly":
 		return &WeeklyRange{t: &now}, nil
+	case "monthly":
+		return &MonthlyRange{t: &now}, nil
+	case "quarterly":
+		return &QuarterlyRange{t: &now}, nil
 	}
 	return nil, fmt.Errorf("Unkown range period: %s", name)
 }
 
+// fixedRangeLayout is the expected format of --from/--to: a plain
+// calendar date, interpreted in the --tz timezone.
+const fixedRangeLayout = "2006-01-02"
+
+// ParseFixedRange parses an explicit --from/--to pair of calendar dates in
+// the given IANA timezone name (an empty tz means UTC). --to is treated
+// as inclusive of the whole day.
+func ParseFixedRange(from, to, tz string) (DateRange, error) {
+	loc := time.UTC
+	if tz != "" {
+		l, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --tz %q: %w", tz, err)
+		}
+		loc = l
+	}
+	f, err := time.ParseInLocation(fixedRangeLayout, from, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --from %q: %w", from, err)
+	}
+	t, err := time.ParseInLocation(fixedRangeLayout, to, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --to %q: %w", to, err)
+	}
+	t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	return &FixedRange{From: f, To: t}, nil
+}
+
+// FixedRange is an arbitrary, inclusive [From, To] range, e.g. as parsed
+// from --from/--to.
 type FixedRange struct {
+	From time.Time
+	To   time.Time
 }
 
 func (r *FixedRange) Include(t *time.Time) bool {
 	if t == nil {
 		return false
 	}
-
-	ry, rm, rd := t.Date()
-	if ry != 2020 {
-		return false
-	}
-	if rm != time.June {
-		return false
-	}
-	if rd < 7 || rd > 13 {
-		return false
-	}
-	return true
+	return !t.Before(r.From) && !t.After(r.To)
 }
 
 // DailyRange for one day
@@ -99,3 +128,35 @@ func (r *WeeklyRange) Include(t *time.Time) bool {
 	}
 	return r.t.Add(-time.Hour * 24 * 7).Before(*t)
 }
+
+// MonthlyRange for one calendar month
+type MonthlyRange struct {
+	t *time.Time
+}
+
+func (r *MonthlyRange) Include(t *time.Time) bool {
+	if t == nil {
+		return false
+	}
+	ly, lm, _ := r.t.Date()
+	ry, rm, _ := t.Date()
+	return ly == ry && lm == rm
+}
+
+// QuarterlyRange for one calendar quarter
+type QuarterlyRange struct {
+	t *time.Time
+}
+
+func quarter(m time.Month) int {
+	return (int(m) - 1) / 3
+}
+
+func (r *QuarterlyRange) Include(t *time.Time) bool {
+	if t == nil {
+		return false
+	}
+	ly, lm, _ := r.t.Date()
+	ry, rm, _ := t.Date()
+	return ly == ry && quarter(lm) == quarter(rm)
+}
@@ -0,0 +1,69 @@
+package main
+
+import "time"
+
+// Repo identifies a repository (or Gerrit project) to report on.
+type Repo struct {
+	Owner string
+	Name  string
+}
+
+func (r Repo) String() string {
+	return r.Owner + "/" + r.Name
+}
+
+// PullRequest is the forge-agnostic view of a GitHub pull request or a
+// Gerrit change.
+type PullRequest struct {
+	Number            int
+	Title             string
+	URL               string
+	Author            string
+	AuthorAssociation string
+	State             string
+	Draft             bool
+	Merged            bool
+	Assignee          string
+	CreatedAt         *time.Time
+	ClosedAt          *time.Time
+	MergedAt          *time.Time
+}
+
+// Review is the forge-agnostic view of a GitHub PR review or a Gerrit
+// Code-Review vote.
+type Review struct {
+	Author            string
+	AuthorAssociation string
+	State             string
+	URL               string
+}
+
+// Issue is the forge-agnostic view of a GitHub issue.
+type Issue struct {
+	Number    int
+	Title     string
+	URL       string
+	Author    string
+	Assignee  string
+	CreatedAt *time.Time
+	ClosedAt  *time.Time
+}
+
+// Forge abstracts over the code review system we're reporting on, so
+// report/contrib/status can run against GitHub, Gerrit, or whatever comes
+// next without caring which one they're talking to.
+type Forge interface {
+	// ListRepos resolves a source string (an org, "owner/repo", or a
+	// forge-specific project path) to the repos it covers.
+	ListRepos(src string) ([]Repo, error)
+	// ListPullRequests lists pull requests/changes for repo in the given
+	// GitHub-style state ("open" or "closed").
+	ListPullRequests(repo Repo, state string) ([]*PullRequest, error)
+	// ListReviews lists the reviews/votes left on a pull request.
+	ListReviews(repo Repo, pr *PullRequest) ([]*Review, error)
+	// ListIssues lists closed issues assigned within org.
+	ListIssues(org string) ([]*Issue, error)
+	// CurrentUser returns the login of the authenticated user, used to
+	// resolve --author me.
+	CurrentUser() (string, error)
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// gerritTimeLayout is the timestamp format Gerrit's REST API uses, e.g.
+// "2020-06-10 12:34:56.000000000".
+const gerritTimeLayout = "2006-01-02 15:04:05.000000000"
+
+// gerritXSSIPrefix is prepended to every Gerrit REST JSON response to guard
+// against cross-site script inclusion; it must be stripped before parsing.
+const gerritXSSIPrefix = ")]}'\n"
+
+// gerritForge implements Forge against a Gerrit host's REST API.
+type gerritForge struct {
+	host     string
+	username string
+	password string
+	http     *http.Client
+}
+
+func newGerritForge(host string, cfg *ForgesConfig) *gerritForge {
+	user, pass, _ := cfg.gerritAuth(host)
+	return &gerritForge{host: host, username: user, password: pass, http: http.DefaultClient}
+}
+
+// ListRepos for Gerrit just echoes back the single project named by src,
+// splitting "host/project" into Owner (the host) and Name (the project).
+func (f *gerritForge) ListRepos(src string) ([]Repo, error) {
+	parts := strings.SplitN(src, "/", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Expected host/project, got: %s", src)
+	}
+	return []Repo{{Owner: parts[0], Name: parts[1]}}, nil
+}
+
+type gerritChangeInfo struct {
+	Number    int                        `json:"_number"`
+	ChangeID  string                     `json:"change_id"`
+	Subject   string                     `json:"subject"`
+	Status    string                     `json:"status"`
+	Owner     gerritAccountInfo          `json:"owner"`
+	Created   string                     `json:"created"`
+	Updated   string                     `json:"updated"`
+	Submitted string                     `json:"submitted"`
+	Labels    map[string]gerritLabelInfo `json:"labels"`
+}
+
+type gerritAccountInfo struct {
+	Username string `json:"username"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+}
+
+func (a gerritAccountInfo) login() string {
+	if a.Username != "" {
+		return a.Username
+	}
+	return a.Email
+}
+
+type gerritLabelInfo struct {
+	All []gerritApprovalInfo `json:"all"`
+}
+
+type gerritApprovalInfo struct {
+	gerritAccountInfo
+	Value int `json:"value"`
+}
+
+func (f *gerritForge) changeURL(repo Repo, number int) string {
+	return fmt.Sprintf("https://%s/c/%s/+/%d", f.host, repo.Name, number)
+}
+
+// gerritStatus maps the GitHub-style "open"/"closed" state used throughout
+// the rest of the tool onto a Gerrit status expression. The closed case is
+// parenthesized since Gerrit's OR is lower precedence than the implicit AND
+// between terms - without it, "project:X status:merged OR status:abandoned"
+// would pull abandoned changes from every project on the host.
+func gerritStatus(state string) string {
+	if state == "closed" {
+		return "(status:merged OR status:abandoned)"
+	}
+	return "status:open"
+}
+
+func (f *gerritForge) ListPullRequests(repo Repo, state string) ([]*PullRequest, error) {
+	query := fmt.Sprintf("project:%s %s", repo.Name, gerritStatus(state))
+	var changes []gerritChangeInfo
+	if err := f.get("/changes/?q="+queryEscape(query)+"&o=DETAILED_ACCOUNTS", &changes); err != nil {
+		return nil, err
+	}
+	res := make([]*PullRequest, 0, len(changes))
+	for i := range changes {
+		c := &changes[i]
+		pr := &PullRequest{
+			Number: c.Number,
+			Title:  c.Subject,
+			URL:    f.changeURL(repo, c.Number),
+			Author: c.Owner.login(),
+			State:  strings.ToLower(c.Status),
+			Merged: c.Status == "MERGED",
+		}
+		if t, err := gerritParseTime(c.Created); err == nil {
+			pr.CreatedAt = t
+		}
+		if c.Status == "MERGED" || c.Status == "ABANDONED" {
+			if t, err := gerritParseTime(c.Updated); err == nil {
+				pr.ClosedAt = t
+				if c.Status == "MERGED" {
+					pr.MergedAt = t
+				}
+			}
+		}
+		res = append(res, pr)
+	}
+	return res, nil
+}
+
+// ListReviews maps Code-Review votes to reviews; a vote of +2 counts as an
+// approval the same way a GitHub "APPROVED" review does.
+func (f *gerritForge) ListReviews(repo Repo, pr *PullRequest) ([]*Review, error) {
+	var detail gerritChangeInfo
+	path := fmt.Sprintf("/changes/%s~%d/detail", repo.Name, pr.Number)
+	if err := f.get(path, &detail); err != nil {
+		return nil, err
+	}
+	label := detail.Labels["Code-Review"]
+	res := make([]*Review, 0, len(label.All))
+	for _, v := range label.All {
+		state := "COMMENTED"
+		if v.Value >= 2 {
+			state = "APPROVED"
+		} else if v.Value < 0 {
+			state = "CHANGES_REQUESTED"
+		}
+		res = append(res, &Review{
+			Author: v.login(),
+			// Gerrit restricts Code-Review voting to project members/
+			// committers, so every voter is at least the GitHub-style
+			// "MEMBER" association cmdContribs' default policy requires.
+			AuthorAssociation: "MEMBER",
+			State:             state,
+			URL:               f.changeURL(repo, pr.Number),
+		})
+	}
+	return res, nil
+}
+
+// ListIssues - Gerrit has no built-in issue tracker, so there's nothing to
+// report; callers should rely on --forge github for issue stats.
+func (f *gerritForge) ListIssues(org string) ([]*Issue, error) {
+	return nil, nil
+}
+
+func (f *gerritForge) CurrentUser() (string, error) {
+	var self gerritAccountInfo
+	if err := f.get("/accounts/self", &self); err != nil {
+		return "", err
+	}
+	return self.login(), nil
+}
+
+func (f *gerritForge) get(path string, out interface{}) error {
+	prefix := "/a"
+	if f.username == "" {
+		prefix = ""
+	}
+	url := fmt.Sprintf("https://%s%s%s", f.host, prefix, path)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+	if f.username != "" {
+		req.SetBasicAuth(f.username, f.password)
+	}
+	resp, err := f.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("gerrit: %s: %s", url, resp.Status)
+	}
+	body = bytes.TrimPrefix(body, []byte(gerritXSSIPrefix))
+	return json.Unmarshal(body, out)
+}
+
+func gerritParseTime(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, fmt.Errorf("empty timestamp")
+	}
+	t, err := time.Parse(gerritTimeLayout, s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func queryEscape(s string) string {
+	return strings.ReplaceAll(s, " ", "+")
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v31/github"
+)
+
+// githubForge implements Forge against the real GitHub API.
+type githubForge struct {
+	client *github.Client
+	ctx    context.Context
+}
+
+func newGithubForge(ctx context.Context, client *github.Client) *githubForge {
+	return &githubForge{client: client, ctx: ctx}
+}
+
+func (f *githubForge) ListRepos(src string) ([]Repo, error) {
+	parts := strings.Split(src, "/")
+	if len(parts) == 1 {
+		repos, _, err := f.client.Repositories.ListByOrg(f.ctx, parts[0], nil)
+		if err != nil {
+			return nil, err
+		}
+		return toRepos(repos), nil
+	} else if len(parts) == 2 {
+		rep, _, err := f.client.Repositories.Get(f.ctx, parts[0], parts[1])
+		if err != nil {
+			return nil, err
+		}
+		return []Repo{{Owner: rep.GetOwner().GetLogin(), Name: rep.GetName()}}, nil
+	}
+	return nil, fmt.Errorf("Unexpected source string: %s", src)
+}
+
+func toRepos(repos []*github.Repository) []Repo {
+	res := make([]Repo, 0, len(repos))
+	for _, r := range repos {
+		res = append(res, Repo{Owner: r.GetOwner().GetLogin(), Name: r.GetName()})
+	}
+	return res
+}
+
+func (f *githubForge) ListPullRequests(repo Repo, state string) ([]*PullRequest, error) {
+	prs, _, err := f.client.PullRequests.List(f.ctx, repo.Owner, repo.Name,
+		&github.PullRequestListOptions{State: state, Sort: "updated"})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		var assignee string
+		if a := pr.GetAssignee(); a != nil {
+			assignee = a.GetLogin()
+		}
+		res = append(res, &PullRequest{
+			Number:            pr.GetNumber(),
+			Title:             pr.GetTitle(),
+			URL:               pr.GetHTMLURL(),
+			Author:            pr.GetUser().GetLogin(),
+			AuthorAssociation: pr.GetAuthorAssociation(),
+			State:             pr.GetState(),
+			Draft:             pr.GetDraft(),
+			Merged:            pr.MergedAt != nil,
+			Assignee:          assignee,
+			CreatedAt:         pr.CreatedAt,
+			ClosedAt:          pr.ClosedAt,
+			MergedAt:          pr.MergedAt,
+		})
+	}
+	return res, nil
+}
+
+func (f *githubForge) ListReviews(repo Repo, pr *PullRequest) ([]*Review, error) {
+	revs, _, err := f.client.PullRequests.ListReviews(f.ctx, repo.Owner, repo.Name, pr.Number, nil)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*Review, 0, len(revs))
+	for _, rev := range revs {
+		res = append(res, &Review{
+			Author:            rev.GetUser().GetLogin(),
+			AuthorAssociation: rev.GetAuthorAssociation(),
+			State:             rev.GetState(),
+			URL:               rev.GetHTMLURL(),
+		})
+	}
+	return res, nil
+}
+
+func (f *githubForge) ListIssues(org string) ([]*Issue, error) {
+	tickets, _, err := f.client.Issues.ListByOrg(f.ctx, org,
+		&github.IssueListOptions{Filter: "assigned", State: "closed"})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*Issue, 0, len(tickets))
+	for _, t := range tickets {
+		var assignee string
+		if t.GetAssignee() != nil {
+			assignee = t.GetAssignee().GetLogin()
+		}
+		res = append(res, &Issue{
+			Number:    t.GetNumber(),
+			Title:     t.GetTitle(),
+			URL:       t.GetHTMLURL(),
+			Author:    t.GetUser().GetLogin(),
+			Assignee:  assignee,
+			CreatedAt: t.CreatedAt,
+			ClosedAt:  t.ClosedAt,
+		})
+	}
+	return res, nil
+}
+
+func (f *githubForge) CurrentUser() (string, error) {
+	user, _, err := f.client.Users.Get(f.ctx, "")
+	if err != nil {
+		return "", err
+	}
+	return user.GetLogin(), nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Person is one human in the Gophers registry, with every login/email they
+// are known to use across forges.
+type Person struct {
+	Logins []string `yaml:"logins"`
+	Emails []string `yaml:"emails"`
+}
+
+// Gophers maps a canonical person name to their known aliases, so the same
+// human isn't double-counted under two GitHub logins or a login and an
+// email address. The name comes from https://github.com/dmitshur/gophers,
+// which does the same thing for the wider Go community.
+type Gophers struct {
+	People map[string]Person `yaml:"people"`
+
+	byAlias map[string]string
+}
+
+// loadGophers reads people.yml if present; a missing file just means no
+// aliases are known yet, which is not an error.
+func loadGophers() (*Gophers, error) {
+	g := &Gophers{People: make(map[string]Person)}
+	data, err := ioutil.ReadFile(configDir() + "/people.yml")
+	if err != nil {
+		if os.IsNotExist(err) {
+			g.index()
+			return g, nil
+		}
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, g); err != nil {
+		return nil, err
+	}
+	g.index()
+	return g, nil
+}
+
+// index builds the alias -> canonical name lookup used by canonicalize.
+func (g *Gophers) index() {
+	g.byAlias = make(map[string]string)
+	for name, person := range g.People {
+		for _, login := range person.Logins {
+			g.byAlias[strings.ToLower(login)] = name
+		}
+		for _, email := range person.Emails {
+			g.byAlias[strings.ToLower(email)] = name
+		}
+	}
+}
+
+// canonicalize resolves a login or email to the canonical name it's
+// registered under, or returns it unchanged if it's not a known alias.
+func (g *Gophers) canonicalize(alias string) string {
+	if g == nil {
+		return alias
+	}
+	if name, ok := g.byAlias[strings.ToLower(alias)]; ok {
+		return name
+	}
+	return alias
+}
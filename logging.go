@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// logger is reporter's root structured logger. Commands derive
+// per-subsystem subloggers from it (e.g. stage=reviews, repo=owner/name)
+// so `--log-level debug` shows exactly why a review or issue was skipped.
+var logger = zerolog.New(os.Stderr).With().Timestamp().Logger()
+
+// logLevel maps the --log-level flag value onto a zerolog.Level.
+func logLevel(name string) (zerolog.Level, error) {
+	switch strings.ToLower(name) {
+	case "", "warn":
+		return zerolog.WarnLevel, nil
+	case "trace":
+		return zerolog.TraceLevel, nil
+	case "debug":
+		return zerolog.DebugLevel, nil
+	case "info":
+		return zerolog.InfoLevel, nil
+	case "error":
+		return zerolog.ErrorLevel, nil
+	default:
+		return zerolog.NoLevel, fmt.Errorf("Unknown log level: %s", name)
+	}
+}
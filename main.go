@@ -1,24 +1,22 @@
 package main
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"github.com/caarlos0/spin"
 	"github.com/google/go-github/v31/github"
 	"github.com/urfave/cli/v2"
 	"golang.org/x/oauth2"
+	"golang.org/x/sync/errgroup"
 	"io/ioutil"
-	"log"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
 )
 
-// GitHub client
-var client *github.Client
-
-// GitHub network context
-var ctx context.Context
+// forge is the code review backend (GitHub, Gerrit, ...) commands talk to.
+var forge Forge
 
 func main() {
 	app := cli.App{
@@ -31,8 +29,28 @@ func main() {
 				Usage: "GitHub API token with",
 			},
 			&cli.StringFlag{
-				Name:  "verbose",
-				Usage: "Verbose output",
+				Name:  "log-level",
+				Value: "warn",
+				Usage: "Log level: trace, debug, info, warn or error",
+			},
+			&cli.StringFlag{
+				Name:  "forge",
+				Value: "github",
+				Usage: "Forge to report against: github or gerrit",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: "text",
+				Usage: "Output format: text, json, csv or md",
+			},
+			&cli.IntFlag{
+				Name:  "concurrency",
+				Value: 4,
+				Usage: "Max number of repos to fetch concurrently",
+			},
+			&cli.BoolFlag{
+				Name:  "no-cache",
+				Usage: "Disable the on-disk HTTP cache",
 			},
 		},
 		Before: setup,
@@ -42,13 +60,7 @@ func main() {
 				Aliases: []string{"rep"},
 				Usage:   "Generate report for period",
 				Action:  cmdRep,
-				Flags: []cli.Flag{
-					&cli.StringFlag{
-						Name:    "period",
-						Aliases: []string{"p"},
-						Value:   "daily",
-						Usage:   "Report period: either daily or weekly",
-					},
+				Flags: append([]cli.Flag{
 					&cli.StringFlag{
 						Name:  "date",
 						Usage: "date of report",
@@ -61,25 +73,19 @@ func main() {
 						Name:  "authors",
 						Usage: "Show PR authors",
 					},
-				},
+				}, rangeFlags...),
 			},
 			&cli.Command{
 				Name:    "contrib",
 				Aliases: []string{"contr"},
 				Usage:   "Generate report for contributors statistics",
 				Action:  cmdContribs,
-				Flags: []cli.Flag{
-					&cli.StringFlag{
-						Name:    "period",
-						Aliases: []string{"p"},
-						Value:   "daily",
-						Usage:   "Report period: either daily or weekly",
-					},
+				Flags: append([]cli.Flag{
 					&cli.StringFlag{
 						Name:  "author",
 						Usage: "Filter by author",
 					},
-				},
+				}, rangeFlags...),
 			},
 			&cli.Command{
 				Name:    "status",
@@ -93,27 +99,165 @@ func main() {
 					},
 				},
 			},
+			&cli.Command{
+				Name:  "people",
+				Usage: "Manage contributor identity mappings",
+				Subcommands: []*cli.Command{
+					&cli.Command{
+						Name:   "discover",
+						Usage:  "Propose email<->login mappings from a repo's PR history",
+						Action: cmdPeopleDiscover,
+					},
+				},
+			},
+			&cli.Command{
+				Name:  "cache",
+				Usage: "Manage the on-disk HTTP cache",
+				Subcommands: []*cli.Command{
+					&cli.Command{
+						Name:   "prune",
+						Usage:  "Delete all cached HTTP responses",
+						Action: cmdCachePrune,
+					},
+				},
+			},
 		},
 	}
 	if err := app.Run(os.Args); err != nil {
-		log.Fatal(err)
+		logger.Fatal().Err(err).Msg("reporter failed")
+	}
+}
+
+// rangeFlags are the --period/--from/--to/--tz flags shared by report and
+// contrib, the two commands that report over a date range.
+var rangeFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:    "period",
+		Aliases: []string{"p"},
+		Value:   "daily",
+		Usage:   "Report period: daily, weekly, monthly or quarterly",
+	},
+	&cli.StringFlag{
+		Name:  "from",
+		Usage: "Start date of an explicit range (requires --to), e.g. 2024-01-01",
+	},
+	&cli.StringFlag{
+		Name:  "to",
+		Usage: "End date of an explicit range (requires --from), e.g. 2024-03-31",
+	},
+	&cli.StringFlag{
+		Name:  "tz",
+		Usage: "Timezone --from/--to are given in, e.g. America/Los_Angeles (default UTC)",
+	},
+}
+
+// period resolves the date range a command should report over, either the
+// named --period or an explicit --from/--to pair.
+func period(app *cli.Context) (DateRange, error) {
+	from, to := app.String("from"), app.String("to")
+	if from != "" || to != "" {
+		if from == "" || to == "" {
+			return nil, fmt.Errorf("--from and --to must be given together")
+		}
+		return ParseFixedRange(from, to, app.String("tz"))
+	}
+	return ParseRange(app.String("period"))
+}
+
+// newSpinner starts a progress spinner, unless a structured --format or
+// --log-level was requested, or stdout isn't a terminal - drawing one
+// there would just corrupt the piped output or interleave with log lines.
+func newSpinner(app *cli.Context) *spin.Spinner {
+	if format := app.String("format"); format != "" && format != "text" {
+		return nil
+	}
+	if app.IsSet("log-level") {
+		return nil
+	}
+	if !isTerminal(os.Stdout) {
+		return nil
+	}
+	s := spin.New(" - %s")
+	s.Set(spin.Spin1)
+	s.Start()
+	return s
+}
+
+func stopSpinner(s *spin.Spinner) {
+	if s == nil {
+		return
 	}
+	s.Stop()
+	fmt.Fprint(os.Stderr, spin.ClearLine)
 }
 
+// GitHub network context
+var ctx context.Context
+
+// forgesConfig holds Gerrit (and future non-GitHub) forge credentials
+// loaded from forges.yml.
+var forgesConfig *ForgesConfig
+
+// gophers resolves a contributor's various logins/emails to one canonical
+// name, loaded from people.yml.
+var gophers *Gophers
+
+// scoringPolicy configures how cmdContribs weighs and filters contributor
+// activity, loaded from scoring.yml.
+var scoringPolicy *ScoringPolicy
+
 func setup(app *cli.Context) error {
 	ctx = context.Background()
-	tkn, err := token(app)
+	lvl, err := logLevel(app.String("log-level"))
+	if err != nil {
+		return err
+	}
+	logger = logger.Level(lvl)
+	cfg, err := loadForgesConfig()
+	if err != nil {
+		return err
+	}
+	forgesConfig = cfg
+	g, err := loadGophers()
+	if err != nil {
+		return err
+	}
+	gophers = g
+	policy, err := loadScoringPolicy()
 	if err != nil {
 		return err
 	}
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: tkn},
-	)
-	tc := oauth2.NewClient(ctx, ts)
-	client = github.NewClient(tc)
+	scoringPolicy = policy
 	return nil
 }
 
+// resolveForge builds the Forge implementation named by --forge. The
+// source string is needed up front for Gerrit, since the host it talks to
+// is embedded in it (e.g. "go-review.googlesource.com/build").
+func resolveForge(app *cli.Context, src string) (Forge, error) {
+	switch name := app.String("forge"); name {
+	case "", "github":
+		tkn, err := token(app)
+		if err != nil {
+			return nil, err
+		}
+		ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: tkn})
+		tc := oauth2.NewClient(ctx, ts)
+		if !app.Bool("no-cache") {
+			tc.Transport = newCachingTransport(tc.Transport)
+		}
+		return newGithubForge(ctx, github.NewClient(tc)), nil
+	case "gerrit":
+		parts := strings.SplitN(src, "/", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("gerrit source must be host/project, got: %s", src)
+		}
+		return newGerritForge(parts[0], forgesConfig), nil
+	default:
+		return nil, fmt.Errorf("Unknown forge: %s", name)
+	}
+}
+
 func token(app *cli.Context) (string, error) {
 	token := app.String("token")
 	if token != "" {
@@ -129,30 +273,48 @@ func token(app *cli.Context) (string, error) {
 	return "", fmt.Errorf("GitHub token neither given as a flag, nor found in env, not in %s", file)
 }
 
-func repos(src string) ([]*github.Repository, error) {
-	parts := strings.Split(src, "/")
-	if len(parts) == 1 {
-		repos, _, err := client.Repositories.ListByOrg(ctx, parts[0], nil)
-		return repos, err
-	} else if len(parts) == 2 {
-		rep, _, err := client.Repositories.Get(ctx, parts[0], parts[1])
-		if err != nil {
-			return nil, err
+// forEachRepo runs fn for each repo with up to concurrency of them
+// in flight at once, stopping at the first error.
+func forEachRepo(repos []Repo, concurrency int, fn func(repo Repo) error) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	g, gctx := errgroup.WithContext(ctx)
+	sem := make(chan struct{}, concurrency)
+	for _, repo := range repos {
+		repo := repo
+		select {
+		case sem <- struct{}{}:
+		case <-gctx.Done():
+			return g.Wait()
 		}
-		return []*github.Repository{rep}, nil
-	} else {
-		return nil, fmt.Errorf("Unexpected source string: %s", src)
+		g.Go(func() error {
+			defer func() { <-sem }()
+			rl := logger.With().Str("stage", "repos").Str("repo", repo.String()).Logger()
+			rl.Debug().Msg("fetching repo")
+			return fn(repo)
+		})
+	}
+	return g.Wait()
+}
+
+func repos(app *cli.Context, src string) ([]Repo, error) {
+	f, err := resolveForge(app, src)
+	if err != nil {
+		return nil, err
 	}
+	forge = f
+	return forge.ListRepos(src)
 }
 
 func author(app *cli.Context) (string, error) {
 	author := app.String("author")
 	if author == "me" {
-		user, _, err := client.Users.Get(ctx, "")
+		user, err := forge.CurrentUser()
 		if err != nil {
 			return "", err
 		}
-		author = user.GetLogin()
+		author = user
 	}
 	if len(author) > 2 && author[0] == '@' {
 		author = author[1:]
@@ -164,12 +326,13 @@ func author(app *cli.Context) (string, error) {
 }
 
 func cmdStat(app *cli.Context) error {
-	fmt.Println("Active pull requests:")
-	s := spin.New(" - %s")
-	s.Set(spin.Spin1)
-	s.Start()
-	defer s.Stop()
-	repos, err := repos(app.Args().First())
+	r, err := newRenderer(app.String("format"), os.Stdout)
+	if err != nil {
+		return err
+	}
+	r.Header("Active pull requests:")
+	s := newSpinner(app)
+	repos, err := repos(app, app.Args().First())
 	if err != nil {
 		return err
 	}
@@ -177,74 +340,72 @@ func cmdStat(app *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	fmt.Print(spin.ClearLine)
-	empty := true
-	for _, repo := range repos {
-		prs, _, err := client.PullRequests.List(ctx, repo.GetOwner().GetLogin(), repo.GetName(),
-			&github.PullRequestListOptions{State: "open", Sort: "updated"})
+	var mu sync.Mutex
+	var items []StatusItem
+	err = forEachRepo(repos, app.Int("concurrency"), func(repo Repo) error {
+		prs, err := forge.ListPullRequests(repo, "open")
 		if err != nil {
 			return err
 		}
+		var local []StatusItem
 		for _, pr := range prs {
-			if strings.HasPrefix(pr.GetUser().GetLogin(), "dependabot") {
+			if scoringPolicy.isExcluded(pr.Author) {
 				continue
 			}
-			if author != "" && author != strings.ToLower(pr.GetUser().GetLogin()) {
+			if author != "" && author != strings.ToLower(pr.Author) {
 				continue
 			}
-			if pr.GetDraft() {
+			if pr.Draft {
 				continue
 			}
-			state := pr.GetState()
-			if pr.GetMerged() {
+			state := pr.State
+			if pr.Merged {
 				state += ":merged"
 			}
-			var assignee string
-			if a := pr.GetAssignee(); a != nil {
-				assignee = fmt.Sprintf("(a:@%s)", a.GetLogin())
-			} else {
-				assignee = "(a:0)"
-			}
-			revs, _, err := client.PullRequests.ListReviews(ctx, repo.GetOwner().GetLogin(), repo.GetName(),
-				pr.GetNumber(), nil)
+			revs, err := forge.ListReviews(repo, pr)
 			if err != nil {
 				return err
 			}
-			revstat := "["
+			var revstat string
 			for _, rev := range revs {
-				if rev.GetState() == "DISMISSED" || rev.GetState() == "COMMENTED" {
+				if rev.State == "DISMISSED" || rev.State == "COMMENTED" || scoringPolicy.isExcluded(rev.Author) {
 					continue
 				}
-				revstat += fmt.Sprintf("%s:%s,", rev.GetUser().GetLogin(), rev.GetState())
+				revstat += fmt.Sprintf("%s:%s,", rev.Author, rev.State)
 			}
-			revstat += "]"
-			fmt.Print(spin.ClearLine)
-			fmt.Printf(" - %s (%s, %s) by @%s %s %s\n",
-				pr.GetTitle(),
-				state,
-				revstat,
-				pr.GetUser().GetLogin(), assignee,
-				pr.GetHTMLURL())
-			empty = false
+			local = append(local, StatusItem{
+				Title:    pr.Title,
+				State:    state,
+				Reviews:  revstat,
+				Author:   pr.Author,
+				Assignee: pr.Assignee,
+				URL:      pr.URL,
+			})
 		}
+		mu.Lock()
+		items = append(items, local...)
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return err
 	}
-	if empty {
-		fmt.Print(spin.ClearLine)
-		fmt.Println(" - None ;)")
-	}
-	return nil
+	stopSpinner(s)
+	return r.Status(items, " - None ;)")
 }
 
 func cmdRep(app *cli.Context) error {
-	rng, err := ParseRange(app.String("period"))
+	rng, err := period(app)
 	if err != nil {
 		return err
 	}
-	s := spin.New(" - %s")
-	s.Set(spin.Spin1)
-	s.Start()
-	defer s.Stop()
-	repos, err := repos(app.Args().First())
+	filter := &TimeFilter{Range: rng}
+	r, err := newRenderer(app.String("format"), os.Stdout)
+	if err != nil {
+		return err
+	}
+	s := newSpinner(app)
+	repos, err := repos(app, app.Args().First())
 	if err != nil {
 		return err
 	}
@@ -253,64 +414,55 @@ func cmdRep(app *cli.Context) error {
 		return err
 	}
 	authors := app.Bool("authors")
-	empty := true
-	var line bytes.Buffer
-	for _, repo := range repos {
-		prs, _, err := client.PullRequests.List(ctx, repo.GetOwner().GetLogin(), repo.GetName(),
-			&github.PullRequestListOptions{State: "closed"})
+	var mu sync.Mutex
+	var items []ReportItem
+	err = forEachRepo(repos, app.Int("concurrency"), func(repo Repo) error {
+		prs, err := forge.ListPullRequests(repo, "closed")
 		if err != nil {
 			return err
 		}
+		var local []ReportItem
 		for _, pr := range prs {
-			closed := pr.GetClosedAt()
 			// don't use pr.Merged since PR list doesn't include this field
 			if pr.MergedAt == nil {
 				continue
 			}
-			if !rng.Include(&closed) {
+			if !filter.CheckPr(pr) {
 				continue
 			}
-			if strings.HasPrefix(pr.GetUser().GetLogin(), "dependabot") {
+			if scoringPolicy.isExcluded(pr.Author) {
 				continue
 			}
-			if author != "" && author != strings.ToLower(pr.GetUser().GetLogin()) {
+			if author != "" && author != strings.ToLower(pr.Author) {
 				continue
 			}
-			line.WriteString(" - ")
-			line.WriteString(pr.GetTitle())
-			if !authors {
-				line.WriteString(" @")
-				line.WriteString(pr.GetUser().GetLogin())
-			}
-			line.WriteString(": ")
-			line.WriteString(pr.GetHTMLURL())
-			fmt.Print(spin.ClearLine)
-			fmt.Println(line.String())
-			line.Reset()
-			empty = false
+			local = append(local, ReportItem{Title: pr.Title, Author: pr.Author, URL: pr.URL})
 		}
+		mu.Lock()
+		items = append(items, local...)
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return err
 	}
-	if empty {
-		fmt.Print(spin.ClearLine)
-		fmt.Println(" - Nothing ;)")
-	}
-	return nil
+	stopSpinner(s)
+	return r.Report(items, authors, " - Nothing ;)")
 }
 
 func cmdContribs(app *cli.Context) error {
-	rng, err := ParseRange(app.String("period"))
+	rng, err := period(app)
 	if err != nil {
 		return err
 	}
-	fmt.Println("Contributors statistics:")
-	verbose := app.Bool("verbose")
-	s := spin.New(" - %s")
-	if !verbose {
-		s.Set(spin.Spin1)
-		s.Start()
-		defer s.Stop()
+	filter := &TimeFilter{Range: rng}
+	r, err := newRenderer(app.String("format"), os.Stdout)
+	if err != nil {
+		return err
 	}
-	repos, err := repos(app.Args().First())
+	r.Header("Contributors statistics:")
+	s := newSpinner(app)
+	repos, err := repos(app, app.Args().First())
 	if err != nil {
 		return err
 	}
@@ -318,40 +470,50 @@ func cmdContribs(app *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	reviewsLog := logger.With().Str("stage", "reviews").Logger()
+	issuesLog := logger.With().Str("stage", "issues").Logger()
+	var mu sync.Mutex
 	stats := usersStats(make(map[string]*userStats))
-	for _, repo := range repos {
-		prs, _, err := client.PullRequests.List(ctx, repo.GetOwner().GetLogin(), repo.GetName(),
-			&github.PullRequestListOptions{State: "closed"})
+	err = forEachRepo(repos, app.Int("concurrency"), func(repo Repo) error {
+		prs, err := forge.ListPullRequests(repo, "closed")
 		if err != nil {
 			return err
 		}
 		for _, pr := range prs {
-			if !rng.Include(pr.ClosedAt) {
+			if !filter.CheckPr(pr) {
 				continue
 			}
-			if strings.HasPrefix(pr.GetUser().GetLogin(), "dependabot") {
+			if scoringPolicy.isExcluded(pr.Author) {
 				continue
 			}
-			rvs, _, err := client.PullRequests.ListReviews(ctx, repo.GetOwner().GetLogin(),
-				repo.GetName(), pr.GetNumber(), nil)
+			rvs, err := forge.ListReviews(repo, pr)
 			if err != nil {
 				return err
 			}
 			// check reviews
 			reviewers := make(map[string]bool)
 			for _, rev := range rvs {
-				if rev.GetAuthorAssociation() != "MEMBER" {
+				if scoringPolicy.isExcluded(rev.Author) {
+					continue
+				}
+				if !scoringPolicy.meetsMinReviewAssociation(rev.AuthorAssociation) {
+					reviewsLog.Debug().Int("pr", pr.Number).Str("reviewer", rev.Author).
+						Str("state", rev.State).Str("action", "skip").Msg("below min review association")
 					continue
 				}
-				state := rev.GetState()
+				state := rev.State
 				if state != "CHANGES_REQUESTED" && state != "APPROVED" {
+					reviewsLog.Debug().Int("pr", pr.Number).Str("reviewer", rev.Author).
+						Str("state", rev.State).Str("action", "skip").Msg("neither approved nor changes requested")
 					continue
 				}
-				reviewers[rev.GetUser().GetLogin()] = true
-				if verbose && author == "" || (author != "" && author == rev.GetUser().GetLogin()) {
-					fmt.Printf("review by %s: %s\n", rev.GetUser().GetLogin(), rev.GetHTMLURL())
+				reviewers[rev.Author] = true
+				if author == "" || author == rev.Author {
+					reviewsLog.Debug().Int("pr", pr.Number).Str("reviewer", rev.Author).
+						Str("state", rev.State).Str("action", "include").Msg("review counted")
 				}
 			}
+			mu.Lock()
 			for reviewer := range reviewers {
 				if author == "" || (author != "" && author == reviewer) {
 					stats.review(reviewer)
@@ -359,50 +521,55 @@ func cmdContribs(app *cli.Context) error {
 			}
 			// check PR merge
 			if pr.MergedAt != nil {
-				user := pr.GetUser().GetLogin()
+				user := pr.Author
 				if author == "" || (author != "" && author == user) {
 					stats.pull(user)
-				}
-				if verbose && author == "" || (author != "" && author == user) {
-					fmt.Printf("PR by %s: %s\n", user, pr.GetHTMLURL())
+					if pr.AuthorAssociation == "FIRST_TIME_CONTRIBUTOR" || pr.AuthorAssociation == "FIRST_TIMER" {
+						stats.firstTime(user)
+					}
 				}
 			}
+			mu.Unlock()
 		}
-
+		return nil
+	})
+	if err != nil {
+		return err
 	}
-	tickets, _, err := client.Issues.ListByOrg(ctx, app.Args().First(),
-		&github.IssueListOptions{Filter: "assigned", State: "closed"})
+	tickets, err := forge.ListIssues(app.Args().First())
 	if err != nil {
 		return err
 	}
 	for _, ticket := range tickets {
-		if !rng.Include(ticket.ClosedAt) {
+		if !filter.CheckTicket(ticket) {
 			continue
 		}
-		if ticket.GetAssignee() == nil ||
-			ticket.GetAssignee().GetLogin() == ticket.GetUser().GetLogin() {
+		if scoringPolicy.isExcluded(ticket.Author) {
 			continue
 		}
-		user := ticket.GetUser().GetLogin()
+		if ticket.Assignee == "" || ticket.Assignee == ticket.Author {
+			issuesLog.Debug().Int("ticket", ticket.Number).Str("author", ticket.Author).
+				Str("action", "skip").Msg("self-assigned or unassigned")
+			continue
+		}
+		user := ticket.Author
 		if author == "" || (author != "" && author == user) {
 			stats.issue(user)
+			issuesLog.Debug().Int("ticket", ticket.Number).Str("author", user).
+				Str("action", "include").Msg("issue counted")
 		}
-		if verbose && author == "" || (author != "" && author == user) {
-			fmt.Printf("Issue by %s: %s\n", user, ticket.GetHTMLURL())
-		}
-
 	}
-	s.Stop()
-	fmt.Print(spin.ClearLine)
-	for name, stats := range stats {
-		fmt.Printf("%s - %s (%f)\n", name, stats, stats.sum())
-	}
-	return nil
+	stopSpinner(s)
+	return r.Contribs(stats, scoringPolicy)
 }
 
 type usersStats map[string]*userStats
 
+// get resolves user to its canonical Gophers name before looking up its
+// stats, so a contributor counted under several logins still gets a
+// single entry.
 func (s usersStats) get(user string) *userStats {
+	user = gophers.canonicalize(user)
 	res := s[user]
 	if res == nil {
 		res = new(userStats)
@@ -426,16 +593,57 @@ func (s usersStats) issue(name string) {
 	us.Issues++
 }
 
+func (s usersStats) firstTime(name string) {
+	us := s.get(name)
+	us.FirstTimePRs++
+}
+
 type userStats struct {
-	Pulls   uint
-	Issues  uint
-	Reviews uint
+	Pulls        uint
+	Issues       uint
+	Reviews      uint
+	FirstTimePRs uint
 }
 
 func (s *userStats) String() string {
-	return fmt.Sprintf("pr=%d rev=%d tic=%d", s.Pulls, s.Reviews, s.Issues)
+	return fmt.Sprintf("pr=%d rev=%d tic=%d first=%d", s.Pulls, s.Reviews, s.Issues, s.FirstTimePRs)
+}
+
+// score weighs the raw counts according to policy, so a dashboard or report
+// can sort/chart contributors by one number.
+func (s *userStats) score(policy *ScoringPolicy) float32 {
+	return float32(s.Pulls)*float32(policy.weight("pull")) +
+		float32(s.Reviews)*float32(policy.weight("review")) +
+		float32(s.Issues)*float32(policy.weight("issue")) +
+		float32(s.FirstTimePRs)*float32(policy.weight("first_time_bonus"))
 }
 
-func (s *userStats) sum() float32 {
-	return float32(s.Pulls) + float32(s.Reviews)*0.5 + float32(s.Issues)*0.5
+// userStatsReport is the policy-scored snapshot of a contributor's stats
+// that the json/csv/md renderers actually emit.
+type userStatsReport struct {
+	Pulls        uint    `json:"pulls"`
+	Reviews      uint    `json:"reviews"`
+	Issues       uint    `json:"issues"`
+	FirstTimePRs uint    `json:"first_time_prs"`
+	Score        float32 `json:"score"`
+}
+
+func (s *userStats) report(policy *ScoringPolicy) userStatsReport {
+	return userStatsReport{s.Pulls, s.Reviews, s.Issues, s.FirstTimePRs, s.score(policy)}
+}
+
+// csvStatsHeader is the header row for userStats.csvRow.
+func csvStatsHeader() []string {
+	return []string{"name", "pulls", "reviews", "issues", "first_time_prs", "score"}
+}
+
+func (s *userStats) csvRow(name string, policy *ScoringPolicy) []string {
+	return []string{
+		name,
+		strconv.FormatUint(uint64(s.Pulls), 10),
+		strconv.FormatUint(uint64(s.Reviews), 10),
+		strconv.FormatUint(uint64(s.Issues), 10),
+		strconv.FormatUint(uint64(s.FirstTimePRs), 10),
+		strconv.FormatFloat(float64(s.score(policy)), 'f', 2, 32),
+	}
 }
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v31/github"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/oauth2"
+)
+
+// cmdPeopleDiscover scans recent merged PRs on a repo and compares each
+// PR author's login against the commit authorship emails it carries, so
+// the user can spot email<->login pairs worth adding to people.yml.
+func cmdPeopleDiscover(app *cli.Context) error {
+	src := app.Args().First()
+	parts := strings.Split(src, "/")
+	if len(parts) != 2 {
+		return fmt.Errorf("Usage: reporter people discover <owner>/<repo>")
+	}
+	owner, name := parts[0], parts[1]
+
+	tkn, err := token(app)
+	if err != nil {
+		return err
+	}
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: tkn})
+	tc := oauth2.NewClient(ctx, ts)
+	client := github.NewClient(tc)
+
+	prs, _, err := client.PullRequests.List(ctx, owner, name,
+		&github.PullRequestListOptions{State: "closed", Sort: "updated"})
+	if err != nil {
+		return err
+	}
+
+	emailsByLogin := make(map[string]map[string]bool)
+	for _, pr := range prs {
+		if pr.MergedAt == nil {
+			continue
+		}
+		login := pr.GetUser().GetLogin()
+		if gophers.canonicalize(login) != login {
+			continue // already mapped
+		}
+		commits, _, err := client.PullRequests.ListCommits(ctx, owner, name, pr.GetNumber(), nil)
+		if err != nil {
+			return err
+		}
+		for _, c := range commits {
+			email := c.GetCommit().GetAuthor().GetEmail()
+			if email == "" || gophers.canonicalize(email) != email {
+				continue
+			}
+			if emailsByLogin[login] == nil {
+				emailsByLogin[login] = make(map[string]bool)
+			}
+			emailsByLogin[login][email] = true
+		}
+	}
+
+	logins := make([]string, 0, len(emailsByLogin))
+	for login := range emailsByLogin {
+		logins = append(logins, login)
+	}
+	sort.Strings(logins)
+	if len(logins) == 0 {
+		fmt.Println("No new email<->login mappings found.")
+		return nil
+	}
+	fmt.Println("Proposed additions to people.yml:")
+	for _, login := range logins {
+		emails := make([]string, 0, len(emailsByLogin[login]))
+		for email := range emailsByLogin[login] {
+			emails = append(emails, email)
+		}
+		sort.Strings(emails)
+		fmt.Printf("  %s:\n    logins: [%s]\n    emails: [%s]\n", login, login, strings.Join(emails, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// StatusItem is one row of `reporter status` output.
+type StatusItem struct {
+	Title    string `json:"title"`
+	State    string `json:"state"`
+	Reviews  string `json:"reviews"`
+	Author   string `json:"author"`
+	Assignee string `json:"assignee"`
+	URL      string `json:"url"`
+}
+
+// ReportItem is one row of `reporter report` output.
+type ReportItem struct {
+	Title  string `json:"title"`
+	Author string `json:"author"`
+	URL    string `json:"url"`
+}
+
+// Renderer turns a command's results into output in one of the supported
+// --format values. Header is only ever shown by the text renderer; the
+// structured ones (json/csv/md) are meant to be piped, not narrated.
+type Renderer interface {
+	Header(msg string)
+	Status(items []StatusItem, emptyMsg string) error
+	Report(items []ReportItem, showAuthors bool, emptyMsg string) error
+	Contribs(stats usersStats, policy *ScoringPolicy) error
+}
+
+// newRenderer builds the Renderer for the given --format value, writing to
+// out (normally os.Stdout).
+func newRenderer(format string, out io.Writer) (Renderer, error) {
+	switch format {
+	case "", "text":
+		return &textRenderer{out: out}, nil
+	case "json":
+		return &jsonRenderer{out: out}, nil
+	case "csv":
+		return &csvRenderer{out: out}, nil
+	case "md":
+		return &mdRenderer{out: out}, nil
+	default:
+		return nil, fmt.Errorf("Unknown format: %s", format)
+	}
+}
+
+// isTerminal reports whether out looks like it's connected to an
+// interactive terminal, in which case it's safe to draw a spinner on it.
+func isTerminal(out io.Writer) bool {
+	f, ok := out.(*os.File)
+	if !ok {
+		return false
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return stat.Mode()&os.ModeCharDevice != 0
+}
+
+// textRenderer reproduces reporter's original, human-oriented output.
+type textRenderer struct {
+	out io.Writer
+}
+
+func (r *textRenderer) Header(msg string) {
+	fmt.Fprintln(r.out, msg)
+}
+
+func (r *textRenderer) Status(items []StatusItem, emptyMsg string) error {
+	if len(items) == 0 {
+		fmt.Fprintln(r.out, emptyMsg)
+		return nil
+	}
+	for _, it := range items {
+		var assignee string
+		if it.Assignee != "" {
+			assignee = fmt.Sprintf("(a:@%s)", it.Assignee)
+		} else {
+			assignee = "(a:0)"
+		}
+		fmt.Fprintf(r.out, " - %s (%s, [%s]) by @%s %s %s\n",
+			it.Title, it.State, it.Reviews, it.Author, assignee, it.URL)
+	}
+	return nil
+}
+
+func (r *textRenderer) Report(items []ReportItem, showAuthors bool, emptyMsg string) error {
+	if len(items) == 0 {
+		fmt.Fprintln(r.out, emptyMsg)
+		return nil
+	}
+	for _, it := range items {
+		line := " - " + it.Title
+		if !showAuthors {
+			line += " @" + it.Author
+		}
+		line += ": " + it.URL
+		fmt.Fprintln(r.out, line)
+	}
+	return nil
+}
+
+func (r *textRenderer) Contribs(stats usersStats, policy *ScoringPolicy) error {
+	for _, name := range stats.names() {
+		s := stats[name]
+		fmt.Fprintf(r.out, "%s - %s (%f)\n", name, s, s.score(policy))
+	}
+	return nil
+}
+
+// jsonRenderer emits a single JSON array/object per call, for piping into
+// dashboards.
+type jsonRenderer struct {
+	out io.Writer
+}
+
+func (r *jsonRenderer) Header(msg string) {}
+
+func (r *jsonRenderer) Status(items []StatusItem, emptyMsg string) error {
+	if items == nil {
+		items = []StatusItem{}
+	}
+	return r.emit(items)
+}
+
+func (r *jsonRenderer) Report(items []ReportItem, showAuthors bool, emptyMsg string) error {
+	if items == nil {
+		items = []ReportItem{}
+	}
+	return r.emit(items)
+}
+
+func (r *jsonRenderer) Contribs(stats usersStats, policy *ScoringPolicy) error {
+	reports := make(map[string]userStatsReport, len(stats))
+	for name, s := range stats {
+		reports[name] = s.report(policy)
+	}
+	return r.emit(reports)
+}
+
+func (r *jsonRenderer) emit(v interface{}) error {
+	enc := json.NewEncoder(r.out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// csvRenderer emits one CSV table per call.
+type csvRenderer struct {
+	out io.Writer
+}
+
+func (r *csvRenderer) Header(msg string) {}
+
+func (r *csvRenderer) Status(items []StatusItem, emptyMsg string) error {
+	w := csv.NewWriter(r.out)
+	w.Write([]string{"title", "state", "reviews", "author", "assignee", "url"})
+	for _, it := range items {
+		w.Write([]string{it.Title, it.State, it.Reviews, it.Author, it.Assignee, it.URL})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func (r *csvRenderer) Report(items []ReportItem, showAuthors bool, emptyMsg string) error {
+	w := csv.NewWriter(r.out)
+	w.Write([]string{"title", "author", "url"})
+	for _, it := range items {
+		w.Write([]string{it.Title, it.Author, it.URL})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func (r *csvRenderer) Contribs(stats usersStats, policy *ScoringPolicy) error {
+	w := csv.NewWriter(r.out)
+	w.Write(csvStatsHeader())
+	for _, name := range stats.names() {
+		w.Write(stats[name].csvRow(name, policy))
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// mdRenderer emits GitHub-flavored markdown tables, suitable for pasting
+// into a weekly report.
+type mdRenderer struct {
+	out io.Writer
+}
+
+func (r *mdRenderer) Header(msg string) {}
+
+func mdTable(out io.Writer, header []string, rows [][]string) {
+	fmt.Fprintf(out, "| %s |\n", join(header, " | "))
+	seps := make([]string, len(header))
+	for i := range seps {
+		seps[i] = "---"
+	}
+	fmt.Fprintf(out, "| %s |\n", join(seps, " | "))
+	for _, row := range rows {
+		fmt.Fprintf(out, "| %s |\n", join(row, " | "))
+	}
+}
+
+func join(parts []string, sep string) string {
+	out := ""
+	for i, p := range parts {
+		if i > 0 {
+			out += sep
+		}
+		out += p
+	}
+	return out
+}
+
+func (r *mdRenderer) Status(items []StatusItem, emptyMsg string) error {
+	if len(items) == 0 {
+		fmt.Fprintln(r.out, emptyMsg)
+		return nil
+	}
+	rows := make([][]string, 0, len(items))
+	for _, it := range items {
+		rows = append(rows, []string{it.Title, it.State, it.Reviews, it.Author, it.Assignee, it.URL})
+	}
+	mdTable(r.out, []string{"title", "state", "reviews", "author", "assignee", "url"}, rows)
+	return nil
+}
+
+func (r *mdRenderer) Report(items []ReportItem, showAuthors bool, emptyMsg string) error {
+	if len(items) == 0 {
+		fmt.Fprintln(r.out, emptyMsg)
+		return nil
+	}
+	rows := make([][]string, 0, len(items))
+	for _, it := range items {
+		rows = append(rows, []string{it.Title, it.Author, it.URL})
+	}
+	mdTable(r.out, []string{"title", "author", "url"}, rows)
+	return nil
+}
+
+func (r *mdRenderer) Contribs(stats usersStats, policy *ScoringPolicy) error {
+	rows := make([][]string, 0, len(stats))
+	for _, name := range stats.names() {
+		rows = append(rows, stats[name].csvRow(name, policy))
+	}
+	mdTable(r.out, csvStatsHeader(), rows)
+	return nil
+}
+
+// names returns the usersStats' user names, sorted for stable output.
+func (s usersStats) names() []string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+
+	"gopkg.in/yaml.v2"
+)
+
+// associationRank orders GitHub's author associations from least to most
+// trusted, so a policy can require e.g. "at least COLLABORATOR" without
+// enumerating every stronger association too.
+var associationRank = map[string]int{
+	"NONE":                   0,
+	"FIRST_TIMER":            1,
+	"FIRST_TIME_CONTRIBUTOR": 1,
+	"CONTRIBUTOR":            2,
+	"COLLABORATOR":           3,
+	"MEMBER":                 4,
+	"OWNER":                  5,
+}
+
+// ScoringPolicy configures how cmdContribs turns raw pull/review/issue
+// counts into a contributor score, and which logins to ignore entirely.
+type ScoringPolicy struct {
+	// Weights maps an event type (pull, review, issue, comment,
+	// first_time_bonus, ...) to the score it contributes. Unknown keys
+	// are harmless, so scoring.yml can carry weights for event types a
+	// future reporter version knows how to count.
+	Weights map[string]float64 `yaml:"weights"`
+	// Exclude is a list of regexes matched against a login; any match
+	// drops that contributor from every report.
+	Exclude []string `yaml:"exclude"`
+	// MinReviewAssociation is the weakest AuthorAssociation a review is
+	// allowed to have to count, e.g. "MEMBER" or "COLLABORATOR".
+	MinReviewAssociation string `yaml:"min_review_association"`
+
+	excludeRE []*regexp.Regexp
+}
+
+// defaultScoringPolicy mirrors reporter's original, hard-coded weights:
+// a pull counts for 1, a review or issue for 0.5, dependabot is ignored,
+// and only reviews from a MEMBER or a more trusted association (OWNER)
+// count.
+func defaultScoringPolicy() *ScoringPolicy {
+	return &ScoringPolicy{
+		Weights: map[string]float64{
+			"pull":   1.0,
+			"review": 0.5,
+			"issue":  0.5,
+		},
+		Exclude:              []string{"^dependabot.*"},
+		MinReviewAssociation: "MEMBER",
+	}
+}
+
+// loadScoringPolicy reads scoring.yml if present, falling back to
+// defaultScoringPolicy when it doesn't exist.
+func loadScoringPolicy() (*ScoringPolicy, error) {
+	policy := defaultScoringPolicy()
+	defaultExclude := append([]string(nil), policy.Exclude...)
+	data, err := ioutil.ReadFile(configDir() + "/scoring.yml")
+	if err != nil {
+		if os.IsNotExist(err) {
+			return policy, policy.compile()
+		}
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, policy); err != nil {
+		return nil, err
+	}
+	// YAML merges the weights map onto the defaults, but replaces the
+	// exclude slice wholesale; merge it by hand so adding one pattern in
+	// scoring.yml doesn't silently re-include dependabot.
+	policy.Exclude = mergeExclude(defaultExclude, policy.Exclude)
+	return policy, policy.compile()
+}
+
+func mergeExclude(defaults, configured []string) []string {
+	seen := make(map[string]bool, len(defaults))
+	merged := append([]string{}, defaults...)
+	for _, p := range defaults {
+		seen[p] = true
+	}
+	for _, p := range configured {
+		if !seen[p] {
+			merged = append(merged, p)
+			seen[p] = true
+		}
+	}
+	return merged
+}
+
+func (p *ScoringPolicy) compile() error {
+	p.excludeRE = make([]*regexp.Regexp, 0, len(p.Exclude))
+	for _, pattern := range p.Exclude {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid scoring exclude pattern %q: %w", pattern, err)
+		}
+		p.excludeRE = append(p.excludeRE, re)
+	}
+	return nil
+}
+
+// isExcluded reports whether login matches one of the policy's exclude
+// patterns, e.g. a bot account.
+func (p *ScoringPolicy) isExcluded(login string) bool {
+	for _, re := range p.excludeRE {
+		if re.MatchString(login) {
+			return true
+		}
+	}
+	return false
+}
+
+// weight returns the configured weight for an event type, or 0 if the
+// policy doesn't mention it.
+func (p *ScoringPolicy) weight(event string) float64 {
+	return p.Weights[event]
+}
+
+// meetsMinReviewAssociation reports whether assoc is at least as trusted
+// as the policy's MinReviewAssociation (MEMBER if unset).
+func (p *ScoringPolicy) meetsMinReviewAssociation(assoc string) bool {
+	min := p.MinReviewAssociation
+	if min == "" {
+		min = "MEMBER"
+	}
+	required, ok := associationRank[min]
+	if !ok {
+		required = associationRank["MEMBER"]
+	}
+	return associationRank[assoc] >= required
+}